@@ -0,0 +1,84 @@
+// Package analyzer defines the backend-agnostic interface the review
+// pipeline uses to turn raw review text into a structured AnalysisResult.
+//
+// A Backend is picked at startup from config (env var or flag) and the
+// rest of the pipeline never has to know whether it's talking to Gemini,
+// an OpenAI-compatible HTTP endpoint, or an out-of-process gRPC plugin.
+package analyzer
+
+import (
+	"context"
+
+	"github.com/hardrich/competitor_weakness_gpt/analyzer/schema"
+)
+
+// AnalysisResult is the structured output produced for a single review.
+// The shape itself (and its JSON Schema / repair logic) lives in the
+// schema package; this alias keeps it at the door every backend uses.
+type AnalysisResult = schema.AnalysisResult
+
+// Analyzer is implemented by every backend capable of classifying review
+// text. Implementations must be safe for concurrent use, since the
+// pipeline calls Analyze from a worker pool.
+type Analyzer interface {
+	// Analyze classifies a single review's text and returns the
+	// structured result, or an error if the backend could not produce
+	// one (including malformed model output it couldn't repair).
+	Analyze(ctx context.Context, reviewText string) (*AnalysisResult, error)
+
+	// Close releases any resources held by the backend (client
+	// connections, subprocesses, etc).
+	Close() error
+}
+
+// Backend names a concrete Analyzer implementation, selected via Config.Backend.
+type Backend string
+
+const (
+	BackendGemini Backend = "gemini"
+	BackendOpenAI Backend = "openai"
+	BackendGRPC   Backend = "grpc"
+)
+
+// Config selects and configures a backend. Only the fields relevant to
+// the chosen Backend need to be set; the rest are ignored.
+type Config struct {
+	Backend Backend
+
+	// Gemini
+	GeminiAPIKey string
+	GeminiModel  string
+
+	// OpenAI-compatible HTTP (also used for LocalAI/Ollama)
+	OpenAIBaseURL string // e.g. http://localhost:11434/v1
+	OpenAIAPIKey  string
+	OpenAIModel   string
+
+	// gRPC plugin backend
+	GRPCAddr        string // host:port the backend subprocess is listening on
+	GRPCBackendsDir string // directory to discover/launch a backend plugin from, if GRPCAddr isn't already set
+}
+
+// New constructs the Analyzer selected by cfg.Backend.
+func New(cfg Config) (Analyzer, error) {
+	switch cfg.Backend {
+	case BackendGemini, "":
+		return newGeminiAnalyzer(cfg)
+	case BackendOpenAI:
+		return newOpenAIAnalyzer(cfg)
+	case BackendGRPC:
+		return newGRPCAnalyzer(cfg)
+	default:
+		return nil, &UnknownBackendError{Backend: cfg.Backend}
+	}
+}
+
+// UnknownBackendError is returned by New when cfg.Backend doesn't match
+// any registered implementation.
+type UnknownBackendError struct {
+	Backend Backend
+}
+
+func (e *UnknownBackendError) Error() string {
+	return "analyzer: unknown backend " + string(e.Backend)
+}
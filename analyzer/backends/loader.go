@@ -0,0 +1,129 @@
+// Package backends discovers and manages external gRPC analyzer
+// backends, the way LocalAI spawns its backend subprocesses: each
+// executable in a directory is its own self-contained model runtime,
+// started on demand and given a local port to listen on.
+package backends
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Plugin is a running backend subprocess.
+type Plugin struct {
+	Name string
+	Addr string
+
+	cmd *exec.Cmd
+}
+
+// Discover lists the executable files directly inside dir. Each one is
+// assumed to be a standalone binary implementing AnalyzerService over
+// gRPC once started (see analyzer/pb/analyze.proto).
+func Discover(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("backends: reading %s: %w", dir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if info.Mode()&0111 == 0 {
+			continue // not executable
+		}
+		names = append(names, entry.Name())
+	}
+	return names, nil
+}
+
+// Launch starts the backend binary at dir/name, handing it an
+// already-bound listening socket (so there's no window in which another
+// process could steal the port) as well as that socket's address via
+// the ANALYZER_GRPC_ADDR environment variable, and waits until it's
+// accepting connections (or startupTimeout elapses).
+func Launch(ctx context.Context, dir, name string, startupTimeout time.Duration) (*Plugin, error) {
+	addr, listenerFile, err := reserveLocalAddr()
+	if err != nil {
+		return nil, fmt.Errorf("backends: reserving a port for %s: %w", name, err)
+	}
+	defer listenerFile.Close()
+
+	path := filepath.Join(dir, name)
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Env = append(os.Environ(), "ANALYZER_GRPC_ADDR="+addr)
+	// Hand the already-bound, already-listening socket to the child as
+	// fd 3 (the systemd socket-activation convention: ExtraFiles[0]
+	// lands right after stdin/stdout/stderr) instead of telling it which
+	// address to bind itself. That closes the window between us
+	// reserving a port and the child binding it, where another process
+	// on the host could otherwise grab it first.
+	cmd.ExtraFiles = []*os.File{listenerFile}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("backends: starting %s: %w", name, err)
+	}
+
+	plugin := &Plugin{Name: name, Addr: addr, cmd: cmd}
+	if err := waitForReady(ctx, addr, startupTimeout); err != nil {
+		_ = plugin.Stop()
+		return nil, fmt.Errorf("backends: %s never became ready: %w", name, err)
+	}
+	return plugin, nil
+}
+
+// Stop terminates the backend subprocess.
+func (p *Plugin) Stop() error {
+	if p.cmd == nil || p.cmd.Process == nil {
+		return nil
+	}
+	return p.cmd.Process.Kill()
+}
+
+// reserveLocalAddr binds a local TCP port and returns both its address
+// and a dup'd file descriptor for the listening socket, so the caller
+// can pass the socket itself to a child process rather than a bare
+// address string the child has to separately (and racily) bind.
+func reserveLocalAddr() (addr string, listenerFile *os.File, err error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+	defer l.Close()
+
+	listenerFile, err = l.(*net.TCPListener).File()
+	if err != nil {
+		return "", nil, fmt.Errorf("duplicating listener fd: %w", err)
+	}
+	return l.Addr().String(), listenerFile, nil
+}
+
+func waitForReady(ctx context.Context, addr string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		dialCtx, cancel := context.WithTimeout(ctx, time.Second)
+		conn, err := grpc.DialContext(dialCtx, addr, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+		cancel()
+		if err == nil {
+			return conn.Close()
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("timed out waiting for %s to accept connections", addr)
+}
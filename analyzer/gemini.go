@@ -0,0 +1,73 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/generative-ai-go/genai"
+	"github.com/hardrich/competitor_weakness_gpt/analyzer/schema"
+	"google.golang.org/api/option"
+)
+
+const defaultGeminiModel = "gemini-2.5-pro"
+
+// geminiAnalyzer talks to the Gemini API directly via the genai SDK.
+// This is the backend the pipeline used exclusively before the analyzer
+// package existed.
+type geminiAnalyzer struct {
+	client *genai.Client
+	model  *genai.GenerativeModel
+}
+
+func newGeminiAnalyzer(cfg Config) (Analyzer, error) {
+	if cfg.GeminiAPIKey == "" {
+		return nil, fmt.Errorf("analyzer: gemini backend requires GeminiAPIKey")
+	}
+
+	modelID := cfg.GeminiModel
+	if modelID == "" {
+		modelID = defaultGeminiModel
+	}
+
+	ctx := context.Background()
+	client, err := genai.NewClient(ctx, option.WithAPIKey(cfg.GeminiAPIKey))
+	if err != nil {
+		return nil, fmt.Errorf("analyzer: creating gemini client: %w", err)
+	}
+
+	model := client.GenerativeModel(modelID)
+	model.ResponseMIMEType = "application/json"
+	model.ResponseSchema = schema.GeminiSchema()
+
+	return &geminiAnalyzer{
+		client: client,
+		model:  model,
+	}, nil
+}
+
+func (a *geminiAnalyzer) Analyze(ctx context.Context, reviewText string) (*AnalysisResult, error) {
+	resp, err := a.model.GenerateContent(ctx, genai.Text(generatePrompt(reviewText)))
+	if err != nil {
+		return nil, fmt.Errorf("analyzer: gemini GenerateContent: %w", err)
+	}
+	if len(resp.Candidates) == 0 || len(resp.Candidates[0].Content.Parts) == 0 {
+		return nil, fmt.Errorf("analyzer: gemini returned no content")
+	}
+
+	var text string
+	for _, part := range resp.Candidates[0].Content.Parts {
+		if t, ok := part.(genai.Text); ok {
+			text += string(t)
+		}
+	}
+
+	result, err := schema.ParseOrRepair([]byte(text))
+	if err != nil {
+		return nil, fmt.Errorf("analyzer: gemini response did not conform to schema: %w", err)
+	}
+	return result, nil
+}
+
+func (a *geminiAnalyzer) Close() error {
+	return a.client.Close()
+}
@@ -0,0 +1,53 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hardrich/competitor_weakness_gpt/analyzer/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// grpcAnalyzer forwards Analyze calls to an external backend process
+// over gRPC. The process is expected to already be listening on
+// cfg.GRPCAddr; spawning/lifecycle of that process is handled by the
+// backends package, not here, since a gRPC client shouldn't need to know
+// how its server was started.
+type grpcAnalyzer struct {
+	conn   *grpc.ClientConn
+	client pb.AnalyzerServiceClient
+}
+
+func newGRPCAnalyzer(cfg Config) (Analyzer, error) {
+	if cfg.GRPCAddr == "" {
+		return nil, fmt.Errorf("analyzer: grpc backend requires GRPCAddr")
+	}
+
+	conn, err := grpc.NewClient(cfg.GRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("analyzer: dialing backend at %s: %w", cfg.GRPCAddr, err)
+	}
+
+	return &grpcAnalyzer{
+		conn:   conn,
+		client: pb.NewAnalyzerServiceClient(conn),
+	}, nil
+}
+
+func (a *grpcAnalyzer) Analyze(ctx context.Context, reviewText string) (*AnalysisResult, error) {
+	resp, err := a.client.Analyze(ctx, &pb.AnalyzeRequest{Text: reviewText})
+	if err != nil {
+		return nil, fmt.Errorf("analyzer: grpc Analyze: %w", err)
+	}
+
+	return &AnalysisResult{
+		Sentiment:  resp.GetSentiment(),
+		Weaknesses: resp.GetWeaknesses(),
+		Theme:      resp.GetTheme(),
+	}, nil
+}
+
+func (a *grpcAnalyzer) Close() error {
+	return a.conn.Close()
+}
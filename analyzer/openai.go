@@ -0,0 +1,135 @@
+package analyzer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/hardrich/competitor_weakness_gpt/analyzer/schema"
+)
+
+const defaultOpenAIModel = "gpt-4o-mini"
+
+// openAIAnalyzer talks to anything that speaks the OpenAI
+// /v1/chat/completions wire format: OpenAI itself, LocalAI, Ollama's
+// OpenAI-compatible endpoint, etc. This is what lets the pipeline run
+// against offline/local models without any code changes.
+type openAIAnalyzer struct {
+	baseURL string
+	apiKey  string
+	model   string
+	http    *http.Client
+}
+
+func newOpenAIAnalyzer(cfg Config) (Analyzer, error) {
+	if cfg.OpenAIBaseURL == "" {
+		return nil, fmt.Errorf("analyzer: openai backend requires OpenAIBaseURL")
+	}
+
+	model := cfg.OpenAIModel
+	if model == "" {
+		model = defaultOpenAIModel
+	}
+
+	return &openAIAnalyzer{
+		baseURL: cfg.OpenAIBaseURL,
+		apiKey:  cfg.OpenAIAPIKey,
+		model:   model,
+		http:    &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+type chatCompletionRequest struct {
+	Model          string          `json:"model"`
+	Messages       []chatMessage   `json:"messages"`
+	ResponseFormat *responseFormat `json:"response_format,omitempty"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type responseFormat struct {
+	Type       string         `json:"type"`
+	JSONSchema *jsonSchemaDoc `json:"json_schema,omitempty"`
+}
+
+type jsonSchemaDoc struct {
+	Name   string `json:"name"`
+	Schema any    `json:"schema"`
+	Strict bool   `json:"strict"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+func (a *openAIAnalyzer) Analyze(ctx context.Context, reviewText string) (*AnalysisResult, error) {
+	reqBody := chatCompletionRequest{
+		Model: a.model,
+		Messages: []chatMessage{
+			{Role: "user", Content: generatePrompt(reviewText)},
+		},
+		ResponseFormat: &responseFormat{
+			Type: "json_schema",
+			JSONSchema: &jsonSchemaDoc{
+				Name:   "analysis_result",
+				Schema: schema.JSONSchema(),
+				Strict: true,
+			},
+		},
+	}
+
+	payload, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("analyzer: marshaling chat completion request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("analyzer: building request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if a.apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+a.apiKey)
+	}
+
+	resp, err := a.http.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("analyzer: calling %s: %w", a.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("analyzer: reading response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("analyzer: %s returned %s: %s", a.baseURL, resp.Status, body)
+	}
+
+	var completion chatCompletionResponse
+	if err := json.Unmarshal(body, &completion); err != nil {
+		return nil, fmt.Errorf("analyzer: unmarshaling chat completion: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return nil, fmt.Errorf("analyzer: %s returned no choices", a.baseURL)
+	}
+
+	result, err := schema.ParseOrRepair([]byte(completion.Choices[0].Message.Content))
+	if err != nil {
+		return nil, fmt.Errorf("analyzer: model output did not conform to schema: %w", err)
+	}
+	return result, nil
+}
+
+func (a *openAIAnalyzer) Close() error {
+	return nil
+}
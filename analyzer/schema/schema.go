@@ -0,0 +1,221 @@
+// Package schema owns the shape of AnalysisResult as a JSON Schema,
+// so every backend can ask its model to conform to it (Gemini's
+// ResponseSchema, OpenAI's response_format), and so malformed output can
+// be repaired instead of dropped outright.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// AnalysisResult is the canonical shape produced by every analyzer
+// backend. It lives here (rather than in the analyzer package) so the
+// schema and the repair logic that targets it stay next to each other;
+// analyzer.AnalysisResult is a type alias to this.
+type AnalysisResult struct {
+	Sentiment  string   `json:"sentiment"`
+	Weaknesses []string `json:"weaknesses"`
+	Theme      string   `json:"theme"`
+
+	// RepairApplied is true when the raw model output didn't parse or
+	// validate on the first try and had to be patched up by Repair.
+	// Downstream analytics use this to track how often a given backend
+	// produces malformed output.
+	RepairApplied bool `json:"repair_applied,omitempty"`
+}
+
+// AllowedSentiments are the only values a conforming sentiment may take.
+var AllowedSentiments = []string{"Positive", "Negative", "Neutral"}
+
+// AllowedThemes are the only values a conforming theme may take. Keep
+// this in sync with the theme list in the prompt.
+var AllowedThemes = []string{
+	"Shipping", "Material", "Functionality", "Performance", "Price",
+	"Support", "Design", "Experience", "Compatibility", "Accuracy",
+	"Maintenance", "Assembly", "General",
+}
+
+const maxWeaknesses = 3
+
+// JSONSchema returns the AnalysisResult shape as a generic JSON Schema
+// document, suitable for OpenAI-compatible backends' response_format.
+func JSONSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"sentiment": map[string]any{
+				"type": "string",
+				"enum": AllowedSentiments,
+			},
+			"weaknesses": map[string]any{
+				"type":     "array",
+				"items":    map[string]any{"type": "string"},
+				"maxItems": maxWeaknesses,
+			},
+			"theme": map[string]any{
+				"type": "string",
+				"enum": AllowedThemes,
+			},
+		},
+		"required":             []string{"sentiment", "weaknesses", "theme"},
+		"additionalProperties": false,
+	}
+}
+
+// GeminiSchema returns the AnalysisResult shape as a *genai.Schema for
+// use with GenerativeModel.ResponseSchema, so Gemini is constrained to
+// produce conforming JSON instead of free-form text.
+func GeminiSchema() *genai.Schema {
+	return &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"sentiment": {
+				Type: genai.TypeString,
+				Enum: AllowedSentiments,
+			},
+			"weaknesses": {
+				Type:        genai.TypeArray,
+				Items:       &genai.Schema{Type: genai.TypeString},
+				Description: fmt.Sprintf("Up to %d lowercase keywords.", maxWeaknesses),
+			},
+			"theme": {
+				Type: genai.TypeString,
+				Enum: AllowedThemes,
+			},
+		},
+		Required: []string{"sentiment", "weaknesses", "theme"},
+	}
+}
+
+// Validate checks that r conforms to the enum and length constraints of
+// the schema. Sentiment and theme are compared case-insensitively, so a
+// value that differs from an allowed one only in case passes unchanged.
+func Validate(r *AnalysisResult) error {
+	if !containsFold(AllowedSentiments, r.Sentiment) {
+		return fmt.Errorf("schema: sentiment %q is not one of %v", r.Sentiment, AllowedSentiments)
+	}
+	if !containsFold(AllowedThemes, r.Theme) {
+		return fmt.Errorf("schema: theme %q is not one of %v", r.Theme, AllowedThemes)
+	}
+	if len(r.Weaknesses) > maxWeaknesses {
+		return fmt.Errorf("schema: weaknesses has %d entries, max is %d", len(r.Weaknesses), maxWeaknesses)
+	}
+	return nil
+}
+
+// ParseOrRepair unmarshals raw model output into an AnalysisResult. If
+// it doesn't parse or doesn't validate on the first try, it falls back
+// to Repair. The returned result's RepairApplied field reflects whether
+// the fallback path was needed.
+func ParseOrRepair(raw []byte) (*AnalysisResult, error) {
+	var result AnalysisResult
+	if err := json.Unmarshal(raw, &result); err == nil {
+		if err := Validate(&result); err == nil {
+			return &result, nil
+		}
+	}
+	return Repair(raw)
+}
+
+// Repair attempts to recover a conforming AnalysisResult from raw model
+// output that failed to parse or validate cleanly:
+//  1. strip markdown code fences the model may have wrapped the JSON in
+//  2. extract the first balanced {...} block via a brace-matching scan
+//  3. coerce theme to the nearest allowed enum via case-insensitive match
+//  4. truncate weaknesses to the schema's max length
+//  5. re-validate; only on success is the result returned
+func Repair(raw []byte) (*AnalysisResult, error) {
+	stripped := stripMarkdownFences(string(raw))
+
+	block, ok := extractJSONObject(stripped)
+	if !ok {
+		return nil, fmt.Errorf("schema: no JSON object found in model output: %s", stripped)
+	}
+
+	var result AnalysisResult
+	if err := json.Unmarshal([]byte(block), &result); err != nil {
+		return nil, fmt.Errorf("schema: could not unmarshal extracted block: %w", err)
+	}
+
+	theme, _ := matchFold(AllowedThemes, result.Theme)
+	result.Theme = theme
+	if len(result.Weaknesses) > maxWeaknesses {
+		result.Weaknesses = result.Weaknesses[:maxWeaknesses]
+	}
+
+	if err := Validate(&result); err != nil {
+		return nil, fmt.Errorf("schema: repaired result still invalid: %w", err)
+	}
+
+	result.RepairApplied = true
+	return &result, nil
+}
+
+// stripMarkdownFences removes a leading/trailing ``` or ```json fence,
+// which models routinely wrap JSON responses in despite being told not to.
+func stripMarkdownFences(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "```json")
+	s = strings.TrimPrefix(s, "```")
+	s = strings.TrimSuffix(s, "```")
+	return strings.TrimSpace(s)
+}
+
+// extractJSONObject scans s for the first balanced {...} block, so
+// stray prose before or after the JSON doesn't break parsing.
+func extractJSONObject(s string) (string, bool) {
+	start := strings.IndexByte(s, '{')
+	if start == -1 {
+		return "", false
+	}
+
+	depth := 0
+	inString := false
+	escaped := false
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\' && inString:
+			escaped = true
+		case c == '"':
+			inString = !inString
+		case inString:
+			// inside a string literal; braces don't count
+		case c == '{':
+			depth++
+		case c == '}':
+			depth--
+			if depth == 0 {
+				return s[start : i+1], true
+			}
+		}
+	}
+	return "", false
+}
+
+func containsFold(options []string, value string) bool {
+	for _, o := range options {
+		if strings.EqualFold(o, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchFold returns the allowed option matching value case-insensitively
+// (in its canonical casing, even if value itself differs only in case),
+// falling back to "General" with found=false if nothing matches.
+func matchFold(options []string, value string) (match string, found bool) {
+	for _, o := range options {
+		if strings.EqualFold(o, value) {
+			return o, true
+		}
+	}
+	return "General", false
+}
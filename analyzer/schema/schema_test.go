@@ -0,0 +1,60 @@
+package schema
+
+import "testing"
+
+func TestRepairNormalizesThemeCasing(t *testing.T) {
+	raw := []byte(`{"sentiment":"negative","weaknesses":["broke"],"theme":"shipping"}`)
+
+	result, err := Repair(raw)
+	if err != nil {
+		t.Fatalf("Repair returned error: %v", err)
+	}
+	if result.Theme != "Shipping" {
+		t.Errorf("Theme = %q, want canonical %q", result.Theme, "Shipping")
+	}
+	if !result.RepairApplied {
+		t.Error("RepairApplied = false, want true")
+	}
+}
+
+func TestRepairTruncatesWeaknesses(t *testing.T) {
+	raw := []byte(`{"sentiment":"Negative","weaknesses":["a","b","c","d"],"theme":"Shipping"}`)
+
+	result, err := Repair(raw)
+	if err != nil {
+		t.Fatalf("Repair returned error: %v", err)
+	}
+	if len(result.Weaknesses) != maxWeaknesses {
+		t.Errorf("len(Weaknesses) = %d, want %d", len(result.Weaknesses), maxWeaknesses)
+	}
+}
+
+func TestRepairExtractsJSONFromMarkdownFence(t *testing.T) {
+	raw := []byte("```json\n{\"sentiment\":\"Positive\",\"weaknesses\":[],\"theme\":\"General\"}\n```")
+
+	result, err := Repair(raw)
+	if err != nil {
+		t.Fatalf("Repair returned error: %v", err)
+	}
+	if result.Sentiment != "Positive" {
+		t.Errorf("Sentiment = %q, want %q", result.Sentiment, "Positive")
+	}
+}
+
+func TestRepairNoJSONObject(t *testing.T) {
+	if _, err := Repair([]byte("no json here")); err == nil {
+		t.Error("expected error for input with no JSON object, got nil")
+	}
+}
+
+func TestParseOrRepairValidInputSkipsRepair(t *testing.T) {
+	raw := []byte(`{"sentiment":"Positive","weaknesses":[],"theme":"General"}`)
+
+	result, err := ParseOrRepair(raw)
+	if err != nil {
+		t.Fatalf("ParseOrRepair returned error: %v", err)
+	}
+	if result.RepairApplied {
+		t.Error("RepairApplied = true for already-conforming input, want false")
+	}
+}
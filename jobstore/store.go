@@ -0,0 +1,246 @@
+// Package jobstore persists per-review analysis jobs in a local BoltDB
+// file so a crashed or interrupted pipeline run can pick up where it
+// left off instead of re-calling the model for reviews it already
+// processed.
+package jobstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Status is the lifecycle state of a single review's analysis job.
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+var jobsBucket = []byte("jobs")
+
+// Job tracks the analysis state for one review, identified by ID. ID
+// must be unique per review, not per reviewer: the well-known Amazon
+// review dataset schema reuses reviewerID across every product a
+// customer reviewed, so callers key jobs on something that actually
+// identifies a single review (e.g. reviewerID+asin+unixReviewTime).
+type Job struct {
+	ID         string `json:"id"`
+	Status     Status `json:"status"`
+	Attempts   int    `json:"attempts"`
+	LastError  string `json:"last_error,omitempty"`
+	ResultJSON string `json:"result_json,omitempty"`
+}
+
+// Store is a BoltDB-backed table of Jobs keyed by Job.ID.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens (creating if necessary) the job database at path.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("jobstore: opening %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(jobsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("jobstore: creating jobs bucket: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database file.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Seed inserts a pending job for each id not already tracked. Reviews
+// already known (from a previous run) are left untouched, which is what
+// makes --resume skip work that's already done or in flight.
+func (s *Store) Seed(ids []string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(jobsBucket)
+		for _, id := range ids {
+			if b.Get([]byte(id)) != nil {
+				continue
+			}
+			job := Job{ID: id, Status: StatusPending}
+			encoded, err := json.Marshal(job)
+			if err != nil {
+				return err
+			}
+			if err := b.Put([]byte(id), encoded); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RetryFailed resets every job currently in StatusFailed back to
+// StatusPending without resetting its attempt count. A retried job that
+// has already reached --max-attempts therefore gets one more attempt
+// before MarkFailed puts it back in StatusFailed.
+func (s *Store) RetryFailed() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(jobsBucket)
+		return b.ForEach(func(k, v []byte) error {
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return err
+			}
+			if job.Status != StatusFailed {
+				return nil
+			}
+			job.Status = StatusPending
+			encoded, err := json.Marshal(job)
+			if err != nil {
+				return err
+			}
+			return b.Put(k, encoded)
+		})
+	})
+}
+
+// Pending returns every job that still needs to be (re-)run: those in
+// StatusPending, plus any stuck in StatusRunning from a process that
+// crashed mid-job.
+func (s *Store) Pending() ([]Job, error) {
+	var jobs []Job
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(jobsBucket)
+		return b.ForEach(func(_, v []byte) error {
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return err
+			}
+			if job.Status == StatusPending || job.Status == StatusRunning {
+				jobs = append(jobs, job)
+			}
+			return nil
+		})
+	})
+	return jobs, err
+}
+
+// Done returns every job that completed successfully, so a resumed run
+// can fold in results from before the crash/restart.
+func (s *Store) Done() ([]Job, error) {
+	var jobs []Job
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(jobsBucket)
+		return b.ForEach(func(_, v []byte) error {
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return err
+			}
+			if job.Status == StatusDone {
+				jobs = append(jobs, job)
+			}
+			return nil
+		})
+	})
+	return jobs, err
+}
+
+// Failed returns every job that exhausted its attempts, so the final
+// report can still surface what couldn't be analyzed and why.
+func (s *Store) Failed() ([]Job, error) {
+	var jobs []Job
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(jobsBucket)
+		return b.ForEach(func(_, v []byte) error {
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return err
+			}
+			if job.Status == StatusFailed {
+				jobs = append(jobs, job)
+			}
+			return nil
+		})
+	})
+	return jobs, err
+}
+
+// MarkRunning transitions a job to StatusRunning and bumps its attempt
+// count, all in one transaction so a crash can't leave attempts
+// under-counted relative to status.
+func (s *Store) MarkRunning(id string) (Job, error) {
+	var job Job
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(jobsBucket)
+		if err := getJob(b, id, &job); err != nil {
+			return err
+		}
+		job.Status = StatusRunning
+		job.Attempts++
+		return putJob(b, job)
+	})
+	return job, err
+}
+
+// MarkDone records a successful result and transitions the job to
+// StatusDone.
+func (s *Store) MarkDone(id, resultJSON string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(jobsBucket)
+		var job Job
+		if err := getJob(b, id, &job); err != nil {
+			return err
+		}
+		job.Status = StatusDone
+		job.ResultJSON = resultJSON
+		job.LastError = ""
+		return putJob(b, job)
+	})
+}
+
+// MarkFailed records an attempt's error. If attempts has reached
+// maxAttempts the job is left in StatusFailed for a later
+// --retry-failed run; otherwise it's put back to StatusPending so the
+// worker pool picks it up again.
+func (s *Store) MarkFailed(id string, attemptErr error, maxAttempts int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(jobsBucket)
+		var job Job
+		if err := getJob(b, id, &job); err != nil {
+			return err
+		}
+		job.LastError = attemptErr.Error()
+		if job.Attempts >= maxAttempts {
+			job.Status = StatusFailed
+		} else {
+			job.Status = StatusPending
+		}
+		return putJob(b, job)
+	})
+}
+
+func getJob(b *bolt.Bucket, id string, job *Job) error {
+	raw := b.Get([]byte(id))
+	if raw == nil {
+		return fmt.Errorf("jobstore: no job with id %s", id)
+	}
+	return json.Unmarshal(raw, job)
+}
+
+func putJob(b *bolt.Bucket, job Job) error {
+	encoded, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(job.ID), encoded)
+}
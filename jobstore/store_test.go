@@ -0,0 +1,110 @@
+package jobstore
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestSeedKeepsDistinctIDsSeparate(t *testing.T) {
+	store := openTestStore(t)
+
+	// Two reviews from the same reviewer (as happens constantly in the
+	// Amazon review dataset, where reviewerID is the customer's ID, not
+	// a per-review key) must seed two distinct jobs, not collapse into
+	// one.
+	ids := []string{"r1|asin-a|100", "r1|asin-b|200"}
+	if err := store.Seed(ids); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+
+	pending, err := store.Pending()
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if len(pending) != len(ids) {
+		t.Fatalf("Pending returned %d jobs, want %d", len(pending), len(ids))
+	}
+}
+
+func TestMarkDoneMovesJobOutOfPending(t *testing.T) {
+	store := openTestStore(t)
+
+	if err := store.Seed([]string{"job-1"}); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	if _, err := store.MarkRunning("job-1"); err != nil {
+		t.Fatalf("MarkRunning: %v", err)
+	}
+	if err := store.MarkDone("job-1", `{"theme":"General"}`); err != nil {
+		t.Fatalf("MarkDone: %v", err)
+	}
+
+	pending, err := store.Pending()
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("Pending returned %d jobs after MarkDone, want 0", len(pending))
+	}
+
+	done, err := store.Done()
+	if err != nil {
+		t.Fatalf("Done: %v", err)
+	}
+	if len(done) != 1 || done[0].ResultJSON != `{"theme":"General"}` {
+		t.Errorf("Done() = %+v, want one job with the stored result", done)
+	}
+}
+
+func TestMarkFailedRetriesUntilMaxAttempts(t *testing.T) {
+	store := openTestStore(t)
+
+	if err := store.Seed([]string{"job-1"}); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+
+	const maxAttempts = 2
+	for i := 0; i < maxAttempts; i++ {
+		if _, err := store.MarkRunning("job-1"); err != nil {
+			t.Fatalf("MarkRunning: %v", err)
+		}
+		if err := store.MarkFailed("job-1", errBoom, maxAttempts); err != nil {
+			t.Fatalf("MarkFailed: %v", err)
+		}
+	}
+
+	failed, err := store.Failed()
+	if err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	if len(failed) != 1 {
+		t.Fatalf("Failed() returned %d jobs, want 1", len(failed))
+	}
+
+	if err := store.RetryFailed(); err != nil {
+		t.Fatalf("RetryFailed: %v", err)
+	}
+	pending, err := store.Pending()
+	if err != nil {
+		t.Fatalf("Pending: %v", err)
+	}
+	if len(pending) != 1 {
+		t.Errorf("Pending() after RetryFailed returned %d jobs, want 1", len(pending))
+	}
+}
+
+var errBoom = &testError{"boom"}
+
+type testError struct{ msg string }
+
+func (e *testError) Error() string { return e.msg }
@@ -0,0 +1,90 @@
+// Package metrics defines the Prometheus instrumentation for the
+// analysis pipeline: request counts and latency by outcome, token usage,
+// and an in-flight gauge, so operators running at scale can see
+// rate-limit hits, theme distribution drift, and per-model cost without
+// parsing free-form logs.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics bundles every Prometheus collector the pipeline reports to.
+// Each Metrics owns its own prometheus.Registry rather than registering
+// on the global DefaultRegisterer, so several worker-pool shards running
+// in the same process (or in tests) never collide with a "duplicate
+// metrics collector registration" panic. Shards running as separate
+// processes each expose their own /metrics and are told apart by the
+// "shard" label below, so one Prometheus job can scrape all of them.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	RequestsTotal   *prometheus.CounterVec
+	RequestDuration *prometheus.HistogramVec
+	TokensTotal     *prometheus.CounterVec
+	Inflight        prometheus.Gauge
+}
+
+// New constructs a Metrics bundle registered on its own registry. shard
+// identifies this process among others sharding the same worker pool
+// (e.g. a hostname or shard index); pass "" if there's only ever one.
+func New(shard string) *Metrics {
+	registry := prometheus.NewRegistry()
+
+	var constLabels prometheus.Labels
+	if shard != "" {
+		constLabels = prometheus.Labels{"shard": shard}
+	}
+
+	m := &Metrics{
+		registry: registry,
+		RequestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "analyzer_requests_total",
+			Help:        "Total analyzer requests, labeled by outcome status and resulting theme.",
+			ConstLabels: constLabels,
+		}, []string{"status", "theme"}),
+		RequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:        "analyzer_request_duration_seconds",
+			Help:        "Analyzer request latency in seconds, labeled by outcome status.",
+			ConstLabels: constLabels,
+			Buckets:     prometheus.DefBuckets,
+		}, []string{"status"}),
+		TokensTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        "analyzer_tokens_total",
+			Help:        "Total tokens exchanged with the model backend, labeled by direction (prompt|completion).",
+			ConstLabels: constLabels,
+		}, []string{"direction"}),
+		Inflight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name:        "analyzer_inflight",
+			Help:        "Number of analyzer requests currently in flight.",
+			ConstLabels: constLabels,
+		}),
+	}
+
+	registry.MustRegister(m.RequestsTotal, m.RequestDuration, m.TokensTotal, m.Inflight)
+	return m
+}
+
+// Handler returns the /metrics HTTP handler serving this Metrics'
+// registry in the Prometheus text exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest records the outcome of one analyzer call: status is
+// "success" or "failure", theme is the resulting AnalysisResult.Theme
+// (empty on failure), and duration is how long the call took.
+func (m *Metrics) ObserveRequest(status, theme string, duration time.Duration) {
+	m.RequestsTotal.WithLabelValues(status, theme).Inc()
+	m.RequestDuration.WithLabelValues(status).Observe(duration.Seconds())
+}
+
+// AddTokens records token usage for one direction ("prompt" or
+// "completion").
+func (m *Metrics) AddTokens(direction string, count int) {
+	m.TokensTotal.WithLabelValues(direction).Add(float64(count))
+}
@@ -0,0 +1,19 @@
+package metrics
+
+// EstimateTokens approximates a token count from raw text length. None
+// of the analyzer backends currently surface the model's own usage
+// counts through the Analyzer interface, so this is the well-known
+// "~4 characters per token" rule of thumb rather than an exact count —
+// good enough to track cost and usage trends over time, not for billing
+// reconciliation.
+func EstimateTokens(text string) int {
+	const charsPerToken = 4
+	if len(text) == 0 {
+		return 0
+	}
+	tokens := len(text) / charsPerToken
+	if tokens == 0 {
+		tokens = 1
+	}
+	return tokens
+}
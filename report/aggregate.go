@@ -0,0 +1,141 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// KeywordCount is one distinct weakness keyword, after stemming-based
+// dedup, and how many times it appeared across all reviews.
+type KeywordCount struct {
+	Keyword string `json:"keyword"`
+	Count   int    `json:"count"`
+}
+
+// WeeklyThemeCounts is the negative-theme histogram for a single ISO
+// week, keyed by the Unix timestamp (UTC) of the week's Monday.
+type WeeklyThemeCounts struct {
+	WeekStart int64          `json:"week_start"`
+	Themes    map[string]int `json:"themes"`
+}
+
+// Report is the full aggregation over a set of analyzed reviews.
+type Report struct {
+	TotalReviews int `json:"total_reviews"`
+
+	// ThemeHistogram[asin][theme] counts how often each theme appears
+	// among that product's reviews.
+	ThemeHistogram map[string]map[string]int `json:"theme_histogram"`
+
+	// SentimentBreakdown counts reviews by sentiment across all products.
+	SentimentBreakdown map[string]int `json:"sentiment_breakdown"`
+
+	// TopKeywords are the most common weakness keywords, deduplicated by
+	// stem (see stem.go) and sorted by descending count.
+	TopKeywords []KeywordCount `json:"top_keywords"`
+
+	// NegativeThemesByWeek is a weekly time series of theme counts among
+	// reviews with sentiment "Negative", ordered by week.
+	NegativeThemesByWeek []WeeklyThemeCounts `json:"negative_themes_by_week"`
+}
+
+// Aggregate builds a Report from a set of analyzed reviews, keeping only
+// the topN most common weakness keywords (all of them if topN <= 0).
+// Reviews whose AnalysisResult is nil (failed analysis) count toward
+// TotalReviews but are otherwise skipped.
+func Aggregate(reviews []ReviewWithAnalysis, topN int) Report {
+	themeHistogram := make(map[string]map[string]int)
+	sentimentBreakdown := make(map[string]int)
+	keywordCounts := make(map[string]*KeywordCount) // stem -> representative + count
+	weekly := make(map[int64]map[string]int)
+
+	for _, r := range reviews {
+		result := r.AnalysisResult
+		if result == nil {
+			continue
+		}
+
+		if themeHistogram[r.ASIN] == nil {
+			themeHistogram[r.ASIN] = make(map[string]int)
+		}
+		themeHistogram[r.ASIN][result.Theme]++
+
+		sentimentBreakdown[result.Sentiment]++
+
+		for _, kw := range result.Weaknesses {
+			addKeyword(keywordCounts, kw)
+		}
+
+		if strings.EqualFold(result.Sentiment, "Negative") {
+			week := weekStart(r.UnixReviewTime)
+			if weekly[week] == nil {
+				weekly[week] = make(map[string]int)
+			}
+			weekly[week][result.Theme]++
+		}
+	}
+
+	return Report{
+		TotalReviews:         len(reviews),
+		ThemeHistogram:       themeHistogram,
+		SentimentBreakdown:   sentimentBreakdown,
+		TopKeywords:          topKeywordList(keywordCounts, topN),
+		NegativeThemesByWeek: weeklyList(weekly),
+	}
+}
+
+// addKeyword folds kw into counts under its stem, using the
+// lowercase/trimmed surface form as the representative label.
+func addKeyword(counts map[string]*KeywordCount, kw string) {
+	label := strings.ToLower(strings.TrimSpace(kw))
+	if label == "" {
+		return
+	}
+	s := stem(label)
+	if existing, ok := counts[s]; ok {
+		existing.Count++
+		return
+	}
+	counts[s] = &KeywordCount{Keyword: label, Count: 1}
+}
+
+func topKeywordList(counts map[string]*KeywordCount, topN int) []KeywordCount {
+	list := make([]KeywordCount, 0, len(counts))
+	for _, kc := range counts {
+		list = append(list, *kc)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Keyword < list[j].Keyword
+	})
+	if topN > 0 && len(list) > topN {
+		list = list[:topN]
+	}
+	return list
+}
+
+func weeklyList(weekly map[int64]map[string]int) []WeeklyThemeCounts {
+	weeks := make([]int64, 0, len(weekly))
+	for w := range weekly {
+		weeks = append(weeks, w)
+	}
+	sort.Slice(weeks, func(i, j int) bool { return weeks[i] < weeks[j] })
+
+	list := make([]WeeklyThemeCounts, 0, len(weeks))
+	for _, w := range weeks {
+		list = append(list, WeeklyThemeCounts{WeekStart: w, Themes: weekly[w]})
+	}
+	return list
+}
+
+// weekStart truncates a Unix timestamp down to UTC midnight on the
+// Monday of the ISO week it falls in.
+func weekStart(unixTime int64) int64 {
+	t := time.Unix(unixTime, 0).UTC()
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	monday := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, -daysSinceMonday)
+	return monday.Unix()
+}
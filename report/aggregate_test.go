@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hardrich/competitor_weakness_gpt/analyzer"
+)
+
+func analyzed(asin string, unixReviewTime int64, result *analyzer.AnalysisResult) ReviewWithAnalysis {
+	return ReviewWithAnalysis{
+		Review:         Review{ASIN: asin, UnixReviewTime: unixReviewTime},
+		AnalysisResult: result,
+	}
+}
+
+func TestAggregateSkipsFailedAnalysesButCountsThem(t *testing.T) {
+	reviews := []ReviewWithAnalysis{
+		analyzed("asin-1", 0, &analyzer.AnalysisResult{Sentiment: "Positive", Theme: "General"}),
+		analyzed("asin-1", 0, nil), // failed analysis
+	}
+
+	rpt := Aggregate(reviews, 0)
+
+	if rpt.TotalReviews != 2 {
+		t.Errorf("TotalReviews = %d, want 2", rpt.TotalReviews)
+	}
+	if got := rpt.ThemeHistogram["asin-1"]["General"]; got != 1 {
+		t.Errorf("ThemeHistogram[asin-1][General] = %d, want 1", got)
+	}
+}
+
+func TestAggregateBucketsThemesByExactString(t *testing.T) {
+	// Aggregate trusts its input to already be schema-normalized (that's
+	// schema.Repair's job); it buckets on the raw Theme string as-is.
+	reviews := []ReviewWithAnalysis{
+		analyzed("asin-1", 0, &analyzer.AnalysisResult{Sentiment: "Negative", Theme: "Shipping"}),
+		analyzed("asin-1", 0, &analyzer.AnalysisResult{Sentiment: "Negative", Theme: "Shipping"}),
+	}
+
+	rpt := Aggregate(reviews, 0)
+
+	if got := rpt.ThemeHistogram["asin-1"]["Shipping"]; got != 2 {
+		t.Errorf("ThemeHistogram[asin-1][Shipping] = %d, want 2", got)
+	}
+}
+
+func TestAggregateDedupsKeywordsByStem(t *testing.T) {
+	reviews := []ReviewWithAnalysis{
+		analyzed("asin-1", 0, &analyzer.AnalysisResult{Sentiment: "Negative", Theme: "Shipping", Weaknesses: []string{"battery"}}),
+		analyzed("asin-1", 0, &analyzer.AnalysisResult{Sentiment: "Negative", Theme: "Shipping", Weaknesses: []string{"batteries"}}),
+	}
+
+	rpt := Aggregate(reviews, 0)
+
+	if len(rpt.TopKeywords) != 1 {
+		t.Fatalf("TopKeywords = %+v, want a single deduped entry", rpt.TopKeywords)
+	}
+	if rpt.TopKeywords[0].Count != 2 {
+		t.Errorf("TopKeywords[0].Count = %d, want 2", rpt.TopKeywords[0].Count)
+	}
+}
+
+func TestAggregateTopNLimitsKeywordCount(t *testing.T) {
+	reviews := []ReviewWithAnalysis{
+		analyzed("asin-1", 0, &analyzer.AnalysisResult{Sentiment: "Negative", Theme: "Shipping", Weaknesses: []string{"broken", "late", "damaged"}}),
+	}
+
+	rpt := Aggregate(reviews, 2)
+
+	if len(rpt.TopKeywords) != 2 {
+		t.Errorf("len(TopKeywords) = %d, want 2", len(rpt.TopKeywords))
+	}
+}
+
+func TestAggregateOnlyBucketsNegativeReviewsWeekly(t *testing.T) {
+	reviews := []ReviewWithAnalysis{
+		analyzed("asin-1", 0, &analyzer.AnalysisResult{Sentiment: "Negative", Theme: "Shipping"}),
+		analyzed("asin-1", 0, &analyzer.AnalysisResult{Sentiment: "Positive", Theme: "General"}),
+	}
+
+	rpt := Aggregate(reviews, 0)
+
+	if len(rpt.NegativeThemesByWeek) != 1 {
+		t.Fatalf("NegativeThemesByWeek = %+v, want exactly one week", rpt.NegativeThemesByWeek)
+	}
+	if got := rpt.NegativeThemesByWeek[0].Themes["Shipping"]; got != 1 {
+		t.Errorf("week Themes[Shipping] = %d, want 1", got)
+	}
+}
+
+func TestWeekStartTruncatesToMonday(t *testing.T) {
+	// 2024-01-10 is a Wednesday; its week starts Monday 2024-01-08 UTC.
+	wednesday := int64(1704844800)  // 2024-01-10T00:00:00Z
+	wantMonday := int64(1704672000) // 2024-01-08T00:00:00Z
+
+	if got := weekStart(wednesday); got != wantMonday {
+		t.Errorf("weekStart(%d) = %d, want %d", wednesday, got, wantMonday)
+	}
+}
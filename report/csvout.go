@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// writeCSVReport writes the per-ASIN theme histogram as CSV, one row per
+// (asin, theme) pair. The other aggregations (top keywords, weekly time
+// series) are nested enough that they're better consumed from the JSON
+// or HTML report.
+func writeCSVReport(rpt Report, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"asin", "theme", "count"}); err != nil {
+		return fmt.Errorf("writing CSV header: %w", err)
+	}
+
+	asins := make([]string, 0, len(rpt.ThemeHistogram))
+	for asin := range rpt.ThemeHistogram {
+		asins = append(asins, asin)
+	}
+	sort.Strings(asins)
+
+	for _, asin := range asins {
+		themes := rpt.ThemeHistogram[asin]
+		names := make([]string, 0, len(themes))
+		for theme := range themes {
+			names = append(names, theme)
+		}
+		sort.Strings(names)
+
+		for _, theme := range names {
+			row := []string{asin, theme, fmt.Sprintf("%d", themes[theme])}
+			if err := w.Write(row); err != nil {
+				return fmt.Errorf("writing CSV row: %w", err)
+			}
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
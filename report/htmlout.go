@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+	"time"
+)
+
+// htmlBar is one row of a simple CSS bar chart: a label, its raw count,
+// and how wide the bar should be drawn relative to the largest value in
+// its chart.
+type htmlBar struct {
+	Label    string
+	Count    int
+	WidthPct float64
+}
+
+type htmlThemeRow struct {
+	ASIN  string
+	Theme string
+	Count int
+}
+
+// htmlData is everything the report template needs, pre-computed so the
+// template itself stays free of arithmetic.
+type htmlData struct {
+	TotalReviews  int
+	SentimentBars []htmlBar
+	KeywordBars   []htmlBar
+	WeeklyBars    []htmlBar
+	ThemeRows     []htmlThemeRow
+}
+
+var reportHTML = template.Must(template.New("report").Parse(reportHTMLTemplate))
+
+// writeHTMLReport renders rpt as a single self-contained HTML file (no
+// external stylesheets, scripts, or fonts) with simple bar charts for
+// each aggregation, suitable for sharing directly with a PM.
+func writeHTMLReport(rpt Report, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	defer f.Close()
+
+	return reportHTML.Execute(f, buildHTMLData(rpt))
+}
+
+func buildHTMLData(rpt Report) htmlData {
+	data := htmlData{TotalReviews: rpt.TotalReviews}
+
+	sentiments := make([]string, 0, len(rpt.SentimentBreakdown))
+	for s := range rpt.SentimentBreakdown {
+		sentiments = append(sentiments, s)
+	}
+	sort.Strings(sentiments)
+	maxSentiment := 0
+	for _, c := range rpt.SentimentBreakdown {
+		maxSentiment = maxInt(maxSentiment, c)
+	}
+	for _, s := range sentiments {
+		count := rpt.SentimentBreakdown[s]
+		data.SentimentBars = append(data.SentimentBars, htmlBar{Label: s, Count: count, WidthPct: barWidth(count, maxSentiment)})
+	}
+
+	maxKeyword := 0
+	for _, kw := range rpt.TopKeywords {
+		maxKeyword = maxInt(maxKeyword, kw.Count)
+	}
+	for _, kw := range rpt.TopKeywords {
+		data.KeywordBars = append(data.KeywordBars, htmlBar{Label: kw.Keyword, Count: kw.Count, WidthPct: barWidth(kw.Count, maxKeyword)})
+	}
+
+	weekTotals := make([]int, len(rpt.NegativeThemesByWeek))
+	maxWeekly := 0
+	for i, week := range rpt.NegativeThemesByWeek {
+		for _, c := range week.Themes {
+			weekTotals[i] += c
+		}
+		maxWeekly = maxInt(maxWeekly, weekTotals[i])
+	}
+	for i, week := range rpt.NegativeThemesByWeek {
+		label := time.Unix(week.WeekStart, 0).UTC().Format("2006-01-02")
+		data.WeeklyBars = append(data.WeeklyBars, htmlBar{Label: label, Count: weekTotals[i], WidthPct: barWidth(weekTotals[i], maxWeekly)})
+	}
+
+	asins := make([]string, 0, len(rpt.ThemeHistogram))
+	for asin := range rpt.ThemeHistogram {
+		asins = append(asins, asin)
+	}
+	sort.Strings(asins)
+	for _, asin := range asins {
+		themes := rpt.ThemeHistogram[asin]
+		names := make([]string, 0, len(themes))
+		for theme := range themes {
+			names = append(names, theme)
+		}
+		sort.Strings(names)
+		for _, theme := range names {
+			data.ThemeRows = append(data.ThemeRows, htmlThemeRow{ASIN: asin, Theme: theme, Count: themes[theme]})
+		}
+	}
+
+	return data
+}
+
+func barWidth(count, max int) float64 {
+	if max == 0 {
+		return 0
+	}
+	return float64(count) / float64(max) * 100
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+const reportHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Competitor Weakness Report</title>
+<style>
+  body { font-family: -apple-system, Helvetica, Arial, sans-serif; margin: 2rem; color: #222; }
+  h1 { margin-bottom: 0.25rem; }
+  h2 { margin-top: 2rem; border-bottom: 1px solid #ddd; padding-bottom: 0.25rem; }
+  .bar-row { display: flex; align-items: center; margin: 4px 0; }
+  .bar-label { width: 180px; flex-shrink: 0; font-size: 0.85rem; text-align: right; padding-right: 8px; }
+  .bar-track { flex: 1; background: #eee; border-radius: 3px; }
+  .bar-fill { height: 16px; background: #3a6ea5; border-radius: 3px; }
+  .bar-count { width: 48px; flex-shrink: 0; padding-left: 8px; font-size: 0.85rem; }
+  table { border-collapse: collapse; margin-top: 0.5rem; }
+  th, td { border: 1px solid #ccc; padding: 4px 10px; font-size: 0.85rem; text-align: left; }
+</style>
+</head>
+<body>
+<h1>Competitor Weakness Report</h1>
+<p>{{.TotalReviews}} reviews analyzed.</p>
+
+<h2>Sentiment breakdown</h2>
+{{range .SentimentBars}}<div class="bar-row">
+  <div class="bar-label">{{.Label}}</div>
+  <div class="bar-track"><div class="bar-fill" style="width: {{.WidthPct}}%;"></div></div>
+  <div class="bar-count">{{.Count}}</div>
+</div>
+{{end}}
+
+<h2>Top weakness keywords</h2>
+{{range .KeywordBars}}<div class="bar-row">
+  <div class="bar-label">{{.Label}}</div>
+  <div class="bar-track"><div class="bar-fill" style="width: {{.WidthPct}}%;"></div></div>
+  <div class="bar-count">{{.Count}}</div>
+</div>
+{{end}}
+
+<h2>Negative themes per week</h2>
+{{range .WeeklyBars}}<div class="bar-row">
+  <div class="bar-label">{{.Label}}</div>
+  <div class="bar-track"><div class="bar-fill" style="width: {{.WidthPct}}%;"></div></div>
+  <div class="bar-count">{{.Count}}</div>
+</div>
+{{end}}
+
+<h2>Theme histogram by ASIN</h2>
+<table>
+<tr><th>ASIN</th><th>Theme</th><th>Count</th></tr>
+{{range .ThemeRows}}<tr><td>{{.ASIN}}</td><td>{{.Theme}}</td><td>{{.Count}}</td></tr>
+{{end}}
+</table>
+</body>
+</html>
+`
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// writeJSONReport writes the full Report as indented JSON.
+func writeJSONReport(rpt Report, path string) error {
+	encoded, err := json.MarshalIndent(rpt, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling report: %w", err)
+	}
+	return os.WriteFile(path, encoded, 0644)
+}
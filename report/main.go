@@ -0,0 +1,82 @@
+// Command report is the competitor-weakness deliverable: it consumes the
+// analyzed_reviews.json produced by the pipeline in script/ and turns it
+// into per-ASIN theme histograms, top weakness keywords, a sentiment
+// breakdown, and a weekly time series of negative themes, written out as
+// JSON, CSV, and a self-contained HTML page a PM can open directly.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/hardrich/competitor_weakness_gpt/analyzer"
+)
+
+// Review mirrors script.Review; this binary only decodes the pipeline's
+// output, so it keeps its own copy of the shape rather than depending on
+// the script package.
+type Review struct {
+	ReviewerID     string  `json:"reviewerID"`
+	ASIN           string  `json:"asin"`
+	ReviewerName   string  `json:"reviewerName"`
+	ReviewText     string  `json:"reviewText"`
+	Overall        float64 `json:"overall"`
+	UnixReviewTime int64   `json:"unixReviewTime"`
+	ReviewTime     string  `json:"reviewTime"`
+}
+
+// ReviewWithAnalysis mirrors script.ReviewWithAnalysis, the shape
+// written to analyzed_reviews.json.
+type ReviewWithAnalysis struct {
+	Review
+	AnalysisResult *analyzer.AnalysisResult
+	Error          string `json:"error,omitempty"`
+}
+
+const defaultTopKeywords = 20
+
+func main() {
+	inputPath := flag.String("input", "analyzed_reviews.json", "path to the analyzed_reviews.json produced by the pipeline")
+	jsonOut := flag.String("json", "report.json", "path to write the JSON report (empty to skip)")
+	csvOut := flag.String("csv", "report.csv", "path to write the CSV theme histogram (empty to skip)")
+	htmlOut := flag.String("html", "report.html", "path to write the self-contained HTML report (empty to skip)")
+	topKeywords := flag.Int("top-keywords", defaultTopKeywords, "number of top weakness keywords to include")
+	flag.Parse()
+
+	raw, err := os.ReadFile(*inputPath)
+	if err != nil {
+		log.Fatalf("Error reading %s: %v", *inputPath, err)
+	}
+
+	var reviews []ReviewWithAnalysis
+	if err := json.Unmarshal(raw, &reviews); err != nil {
+		log.Fatalf("Error unmarshaling %s: %v", *inputPath, err)
+	}
+
+	log.Printf("Loaded %d analyzed reviews from %s\n", len(reviews), *inputPath)
+
+	rpt := Aggregate(reviews, *topKeywords)
+
+	if *jsonOut != "" {
+		if err := writeJSONReport(rpt, *jsonOut); err != nil {
+			log.Fatalf("Error writing JSON report: %v", err)
+		}
+		log.Printf("Wrote JSON report to %s\n", *jsonOut)
+	}
+
+	if *csvOut != "" {
+		if err := writeCSVReport(rpt, *csvOut); err != nil {
+			log.Fatalf("Error writing CSV report: %v", err)
+		}
+		log.Printf("Wrote CSV report to %s\n", *csvOut)
+	}
+
+	if *htmlOut != "" {
+		if err := writeHTMLReport(rpt, *htmlOut); err != nil {
+			log.Fatalf("Error writing HTML report: %v", err)
+		}
+		log.Printf("Wrote HTML report to %s\n", *htmlOut)
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import "strings"
+
+// stem reduces a weakness keyword to a crude canonical form so that
+// simple plural/inflection variants ("battery"/"batteries",
+// "scratch"/"scratches") collapse into the same bucket when counting.
+// It's deliberately not a full stemmer (no Porter algorithm, no
+// irregular forms) — just enough suffix-stripping for the short,
+// model-generated keyword lists this report aggregates.
+func stem(word string) string {
+	w := strings.ToLower(strings.TrimSpace(word))
+	switch {
+	case strings.HasSuffix(w, "ies") && len(w) > 4:
+		return w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "ses") && len(w) > 4:
+		return w[:len(w)-2]
+	case strings.HasSuffix(w, "es") && len(w) > 3:
+		return w[:len(w)-2]
+	case strings.HasSuffix(w, "ss"):
+		return w
+	case strings.HasSuffix(w, "s") && len(w) > 3:
+		return w[:len(w)-1]
+	default:
+		return w
+	}
+}
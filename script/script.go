@@ -2,21 +2,28 @@ package main
 
 import (
 	"context"
-    "encoding/json"
-    "fmt"
-    "log"
-    "os"
-    "sync"
-
-    "github.com/joho/godotenv" // For loading .env file
-    "google.golang.org/api/option"
-
-    // The correct import for the Generative Language API *client*
-    genai "cloud.google.com/go/generativelanguage/apiv1beta"
-    // The correct import for the Generative Language API *protobuf types*
-    genai_pb "cloud.google.com/genproto/googleapis/cloud/generativelanguage/v1beta/generativelanguagepb"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"math"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hardrich/competitor_weakness_gpt/analyzer"
+	"github.com/hardrich/competitor_weakness_gpt/analyzer/backends"
+	"github.com/hardrich/competitor_weakness_gpt/jobstore"
+	"github.com/hardrich/competitor_weakness_gpt/metrics"
+	"github.com/joho/godotenv" // For loading .env file
 )
 
+// backendLaunchTimeout bounds how long we'll wait for a spawned backend
+// plugin to start accepting gRPC connections before giving up.
+const backendLaunchTimeout = 10 * time.Second
+
 // Review represents the structure of a single review in your JSON file.
 type Review struct {
 	ReviewerID     string  `json:"reviewerID"`
@@ -28,130 +35,172 @@ type Review struct {
 	ReviewTime     string  `json:"reviewTime"`
 }
 
-// AnalysisResult represents the structure of the JSON output from the AI model.
-type AnalysisResult struct {
-	Sentiment  string   `json:"sentiment"`
-	Weaknesses []string `json:"weaknesses"`
-	Theme      string   `json:"theme"`
-}
-
 // ReviewWithAnalysis combines the original review with its analysis result.
 type ReviewWithAnalysis struct {
 	Review
-	AnalysisResult *AnalysisResult // Pointer to allow nil if analysis fails
-	Error          string          `json:"error,omitempty"` // To store any error during analysis
+	AnalysisResult *analyzer.AnalysisResult // Pointer to allow nil if analysis fails
+	Error          string                   `json:"error,omitempty"` // To store any error during analysis
 }
 
 const (
-	reviewsFilePath       = "mapped.json"    // Path to your input JSON file
-	batchSize             = 5                // Number of reviews to process in each batch
-	maxParallelGoRoutines = 10               // Max concurrent API requests
-	geminiModelID         = "gemini-2.5-pro" // Or "gemini-1.0-pro-001"
-	// Adjust based on your region; 'us-central1' is common.
-	apiEndpoint = "us-central1-aiplatform.googleapis.com:443"
+	reviewsFilePath       = "mapped.json" // Path to your input JSON file
+	maxParallelGoRoutines = 10            // Max concurrent API requests
+	backoffBase           = 500 * time.Millisecond
 )
 
-// generatePrompt creates the specific prompt string for the AI model.
-func generatePrompt(reviewText string) string {
-	return fmt.Sprintf(`You are an expert e-commerce product review analyst. Your task is to analyze a given product review and return a JSON object strictly adhering to the specified structure and rules.
-
-Here are the strict rules for your analysis and JSON output:
-
-- **sentiment**: A single enumerated value: "Positive", "Negative", or "Neutral". This should reflect the overall tone and customer satisfaction expressed in the review.
-- **weaknesses**: A list containing up to 3 (three) lowercase keywords. These keywords must represent the primary issues, flaws, or negative aspects explicitly mentioned in the review. If no clear weaknesses are identified, this list must be empty ([]).
-- **theme**: A single, singular keyword representing the main category of the feedback or issue. Choose from the following options. Select the most relevant one. If the review is generally positive with no specific issues, or if the primary theme doesn't fit any of these, use "General".
-
-    * **Shipping**: Problems related to delivery, packaging, delays, or received condition (e.g., damaged box, late arrival).
-    * **Material**: Issues concerning the physical composition, build quality, durability, or integrity of the product (e.g., "cheap plastic," "broke easily," "thin fabric").
-    * **Functionality**: Problems with how the product operates, performs its intended purpose, or its features (e.g., "doesn't charge," "button sticks," "software glitch," "didn't work").
-    * **Performance**: Related to efficiency, speed, effectiveness, or power output (e.g., "slow," "not powerful enough," "battery drains fast").
-    * **Price**: Comments on the cost, value for money, or affordability of the product (e.g., "too expensive," "not worth the price," "great value").
-    * **Support**: Issues with customer service, warranty, returns, or technical assistance (e.g., "bad customer service," "no reply," "difficult return").
-    * **Design**: Feedback on the aesthetics, ergonomics, user-friendliness, or appearance (e.g., "ugly," "uncomfortable," "clunky design").
-    * **Experience**: Pertains to the overall user interaction, ease of use, setup process, or unboxing (e.g., "hard to set up," "complicated," "smooth experience").
-    * **Compatibility**: Problems with the product working with other devices, systems, or requirements (e.g., "doesn't fit," "not compatible with iOS").
-    * **Accuracy**: Issues where the product description, specifications, or advertised features do not match the actual product (e.g., "wrong color," "smaller than described," "misleading image").
-    * **Maintenance**: Difficulties with cleaning, upkeep, or long-term care of the product (e.g., "hard to clean," "requires constant maintenance").
-    * **Assembly**: Challenges related to putting the product together (e.g., "difficult to assemble," "missing parts").
-    * **General**: For overwhelmingly positive reviews without specific issues, or for issues that don't fit well into the other categories.
-
-Ensure the output is **strictly a valid JSON object** and nothing else. Do not include any explanatory text or conversational elements outside the JSON.
-
-**Review to analyze:**
-%s`, reviewText) // %s will be replaced by reviewText
-}
+// loadAnalyzerConfig builds an analyzer.Config from the environment. The
+// backend defaults to Gemini (the original behavior) but can be switched
+// with ANALYZER_BACKEND=openai|grpc so the pipeline can run against a
+// local model without any code changes.
+func loadAnalyzerConfig() analyzer.Config {
+	backend := analyzer.Backend(os.Getenv("ANALYZER_BACKEND"))
 
-// analyzeReview calls the Gemini API for a single review.
-func analyzeReview(ctx context.Context, client *genai.PredictionClient, projectID, locationID, reviewText string) (*AnalysisResult, error) {
-	prompt := generatePrompt(reviewText)
-
-	// Construct the request for the Gemini model
-	// The Gemini API typically expects the input within a 'content' field,
-	// which then contains 'parts' (e.g., text, images).
-	// We'll create the instance as a structpb.Struct.
-	instance, err := structpb.NewStruct(map[string]interface{}{
-		"content": map[string]interface{}{
-			"parts": []interface{}{
-				map[string]interface{}{
-					"text": prompt,
-				},
-			},
-		},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create instance struct: %w", err)
+	return analyzer.Config{
+		Backend: backend,
+
+		GeminiAPIKey: os.Getenv("GEMINI_API_KEY"),
+		GeminiModel:  os.Getenv("GEMINI_MODEL"),
+
+		OpenAIBaseURL: os.Getenv("OPENAI_BASE_URL"),
+		OpenAIAPIKey:  os.Getenv("OPENAI_API_KEY"),
+		OpenAIModel:   os.Getenv("OPENAI_MODEL"),
+
+		GRPCAddr:        os.Getenv("ANALYZER_GRPC_ADDR"),
+		GRPCBackendsDir: os.Getenv("ANALYZER_BACKENDS_DIR"),
 	}
+}
 
-	req := &genaipb.PredictRequest{
-		Endpoint: fmt.Sprintf("projects/%s/locations/%s/publishers/google/models/%s", projectID, locationID, geminiModelID),
-		Instances: []*structpb.Value{ // Explicitly use []*structpb.Value here
-			structpb.NewStructValue(instance),
-		},
+// launchBackendPlugin autoloads a backend from cfg.GRPCBackendsDir when the
+// grpc backend is selected but no address was given explicitly: it
+// discovers the executables in that directory, spawns the first one, and
+// points cfg at the address it ends up listening on. The caller is
+// responsible for stopping the returned plugin (nil if nothing was
+// launched) once it's done with the analyzer client.
+func launchBackendPlugin(ctx context.Context, cfg *analyzer.Config) (*backends.Plugin, error) {
+	if cfg.Backend != analyzer.BackendGRPC || cfg.GRPCAddr != "" || cfg.GRPCBackendsDir == "" {
+		return nil, nil
 	}
 
-	resp, err := client.Predict(ctx, req)
+	names, err := backends.Discover(cfg.GRPCBackendsDir)
 	if err != nil {
-		return nil, fmt.Errorf("failed to call Predict API: %w", err)
+		return nil, fmt.Errorf("discovering backends in %s: %w", cfg.GRPCBackendsDir, err)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no backend executables found in %s", cfg.GRPCBackendsDir)
 	}
 
-	// ... rest of your response handling code ...
-	if len(resp.Predictions) == 0 || resp.Predictions[0].GetStructValue() == nil {
-		return nil, fmt.Errorf("no predictions returned or unexpected format")
+	name := names[0]
+	slog.Info("launching backend plugin", "dir", cfg.GRPCBackendsDir, "name", name)
+	plugin, err := backends.Launch(ctx, cfg.GRPCBackendsDir, name, backendLaunchTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("launching backend %s: %w", name, err)
 	}
 
-	// Extract the generated text (the JSON string)
-	// The response structure might also be a structpb.StructValue
-	generatedContent := resp.Predictions[0].GetStructValue().Fields["content"].GetStringValue()
-	if generatedContent == "" {
-		// Fallback for models that might return directly as 'text' or other fields
-		if textValue, ok := resp.Predictions[0].GetStructValue().Fields["text"]; ok {
-			generatedContent = textValue.GetStringValue()
-		} else {
-			return nil, fmt.Errorf("generated content is empty and no 'text' field found")
+	cfg.GRPCAddr = plugin.Addr
+	return plugin, nil
+}
+
+// promMux serves m's Prometheus registry on /metrics.
+func promMux(m *metrics.Metrics) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	return mux
+}
+
+// reviewJobID returns the job store key for r. reviewerID alone isn't
+// enough: in the Amazon review dataset it's the customer's ID and is
+// reused across every product they reviewed, so keying on it alone would
+// collapse all but one of a reviewer's reviews into a single job.
+// asin+unixReviewTime narrows that back down to one specific review.
+func reviewJobID(r Review) string {
+	return fmt.Sprintf("%s|%s|%d", r.ReviewerID, r.ASIN, r.UnixReviewTime)
+}
+
+// processJob runs review through client, retrying with exponential
+// backoff up to maxAttempts before giving up. Every attempt (success or
+// failure) is recorded in store so a crash mid-run can be resumed, and
+// reported to m so operators can see rate-limit hits and theme drift
+// without parsing logs.
+func processJob(ctx context.Context, client analyzer.Analyzer, store *jobstore.Store, m *metrics.Metrics, review Review, maxAttempts int) {
+	jobID := reviewJobID(review)
+
+	for {
+		job, err := store.MarkRunning(jobID)
+		if err != nil {
+			slog.Error("job store error", "job_id", jobID, "reviewer_id", review.ReviewerID, "asin", review.ASIN, "error", err)
+			return
 		}
-	}
 
-	var analysisResult AnalysisResult
-	err = json.Unmarshal([]byte(generatedContent), &analysisResult)
-	if err != nil {
-		log.Printf("Warning: Could not unmarshal generated content into JSON. Raw content: %s, Error: %v\n", generatedContent, err)
-		return nil, fmt.Errorf("failed to unmarshal analysis result: %w", err)
-	}
+		m.Inflight.Inc()
+		start := time.Now()
+		result, analyzeErr := client.Analyze(ctx, review.ReviewText)
+		latency := time.Since(start)
+		m.Inflight.Dec()
+
+		m.AddTokens("prompt", metrics.EstimateTokens(review.ReviewText))
+
+		if analyzeErr == nil {
+			resultJSON, err := json.Marshal(result)
+			if err != nil {
+				slog.Error("marshaling result", "job_id", jobID, "reviewer_id", review.ReviewerID, "asin", review.ASIN, "attempt", job.Attempts, "error", err)
+				return
+			}
+			m.AddTokens("completion", metrics.EstimateTokens(string(resultJSON)))
+			m.ObserveRequest("success", result.Theme, latency)
+
+			if err := store.MarkDone(jobID, string(resultJSON)); err != nil {
+				slog.Error("recording result", "job_id", jobID, "reviewer_id", review.ReviewerID, "asin", review.ASIN, "attempt", job.Attempts, "error", err)
+			}
+			slog.Info("analysis succeeded", "job_id", jobID, "reviewer_id", review.ReviewerID, "asin", review.ASIN, "attempt", job.Attempts, "latency_ms", latency.Milliseconds(), "theme", result.Theme)
+			return
+		}
+
+		m.ObserveRequest("failure", "", latency)
+		slog.Warn("analysis attempt failed", "job_id", jobID, "reviewer_id", review.ReviewerID, "asin", review.ASIN, "attempt", job.Attempts, "max_attempts", maxAttempts, "latency_ms", latency.Milliseconds(), "error", analyzeErr)
+
+		if err := store.MarkFailed(jobID, analyzeErr, maxAttempts); err != nil {
+			slog.Error("recording failure", "job_id", jobID, "reviewer_id", review.ReviewerID, "asin", review.ASIN, "attempt", job.Attempts, "error", err)
+			return
+		}
+		if job.Attempts >= maxAttempts {
+			return
+		}
 
-	return &analysisResult, nil
+		backoff := time.Duration(float64(backoffBase) * math.Pow(2, float64(job.Attempts-1)))
+		time.Sleep(backoff)
+	}
 }
 
 func main() {
+	dbPath := flag.String("db", "reviews.db", "path to the job store database")
+	resume := flag.Bool("resume", false, "reuse an existing job store instead of starting fresh")
+	retryFailed := flag.Bool("retry-failed", false, "reset jobs that previously exhausted their attempts back to pending")
+	maxAttempts := flag.Int("max-attempts", 5, "maximum analysis attempts per review before giving up")
+	metricsAddr := flag.String("metrics-addr", ":9090", "address to serve Prometheus metrics on")
+	shard := flag.String("shard", "", "identifier for this process when the worker pool is sharded across processes (added as a 'shard' metric label)")
+	flag.Parse()
+
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+
 	// Load environment variables from .env file
-	err := godotenv.Load()
-	if err != nil {
+	if err := godotenv.Load(); err != nil {
 		log.Fatalf("Error loading .env file, ensure it exists and contains GEMINI_API_KEY: %v", err)
 	}
 
-	// apiKey := os.Getenv("GEMINI_API_KEY")
-	// if apiKey == "" {
-	// 	log.Fatal("GEMINI_API_KEY not found in environment variables. Please set it.")
-	// }
+	m := metrics.New(*shard)
+	metricsServer := &http.Server{Addr: *metricsAddr, Handler: promMux(m)}
+	go func() {
+		if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.Error("metrics server stopped", "error", err)
+		}
+	}()
+	defer metricsServer.Close()
+
+	if !*resume {
+		if err := os.Remove(*dbPath); err != nil && !os.IsNotExist(err) {
+			log.Fatalf("Error clearing previous job store %s: %v", *dbPath, err)
+		}
+	}
 
 	// Read reviews from JSON file
 	fileContent, err := os.ReadFile(reviewsFilePath)
@@ -160,76 +209,111 @@ func main() {
 	}
 
 	var reviews []Review
-	err = json.Unmarshal(fileContent, &reviews)
-	if err != nil {
+	if err := json.Unmarshal(fileContent, &reviews); err != nil {
 		log.Fatalf("Error unmarshaling reviews JSON: %v", err)
 	}
 
-	log.Printf("Successfully loaded %d reviews from %s\n", len(reviews), reviewsFilePath)
+	slog.Info("loaded reviews", "count", len(reviews), "path", reviewsFilePath)
+
+	reviewsByJobID := make(map[string]Review, len(reviews))
+	jobIDs := make([]string, 0, len(reviews))
+	for _, r := range reviews {
+		id := reviewJobID(r)
+		reviewsByJobID[id] = r
+		jobIDs = append(jobIDs, id)
+	}
+
+	store, err := jobstore.Open(*dbPath)
+	if err != nil {
+		log.Fatalf("Failed to open job store: %v", err)
+	}
+	defer store.Close()
+
+	if *retryFailed {
+		if err := store.RetryFailed(); err != nil {
+			log.Fatalf("Failed to reset failed jobs: %v", err)
+		}
+	}
+
+	if err := store.Seed(jobIDs); err != nil {
+		log.Fatalf("Failed to seed job store: %v", err)
+	}
+
+	pending, err := store.Pending()
+	if err != nil {
+		log.Fatalf("Failed to list pending jobs: %v", err)
+	}
+	slog.Info("pending analysis", "count", len(pending))
 
-	// Initialize Gemini client
 	ctx := context.Background()
-	client, err := genai.NewPredictionClient(ctx, option.WithEndpoint(apiEndpoint))
+
+	analyzerCfg := loadAnalyzerConfig()
+	backendPlugin, err := launchBackendPlugin(ctx, &analyzerCfg)
 	if err != nil {
-		log.Fatalf("Failed to create Gemini client: %v", err)
+		log.Fatalf("Failed to launch backend plugin: %v", err)
+	}
+	if backendPlugin != nil {
+		defer backendPlugin.Stop()
+	}
+
+	client, err := analyzer.New(analyzerCfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize analyzer backend: %v", err)
 	}
 	defer client.Close()
 
-	projectID := os.Getenv("GCP_PROJECT_ID") // Optional: set if needed, usually managed by ADC
-	if projectID == "" {
-		log.Println("GCP_PROJECT_ID not set. API calls might default to a project configured via gcloud CLI.")
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxParallelGoRoutines)
+
+	for _, job := range pending {
+		review, ok := reviewsByJobID[job.ID]
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(r Review) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			processJob(ctx, client, store, m, r, *maxAttempts)
+		}(review)
 	}
-	locationID := "us-central1" // Ensure this matches your API endpoint region
 
-	var allAnalyzedReviews []ReviewWithAnalysis
-	var wg sync.WaitGroup // WaitGroup to wait for all goroutines to finish
+	wg.Wait()
 
-	// Semaphore to limit concurrent goroutines (API requests)
-	sem := make(chan struct{}, maxParallelGoRoutines)
+	done, err := store.Done()
+	if err != nil {
+		log.Fatalf("Failed to collect completed jobs: %v", err)
+	}
 
-	for i := 0; i < len(reviews); i += batchSize {
-		end := i + batchSize
-		if end > len(reviews) {
-			end = len(reviews)
+	allAnalyzedReviews := make([]ReviewWithAnalysis, 0, len(done))
+	for _, job := range done {
+		review, ok := reviewsByJobID[job.ID]
+		if !ok {
+			continue
 		}
-		batch := reviews[i:end]
-
-		log.Printf("Processing batch %d to %d\n", i, end-1)
-
-		for _, review := range batch {
-			wg.Add(1)
-			sem <- struct{}{} // Acquire a slot in the semaphore
-			go func(r Review) {
-				defer wg.Done()
-				defer func() { <-sem }() // Release the slot when goroutine finishes
-
-				log.Printf("  Analyzing review: %s (ID: %s)\n", r.ReviewText, r.ReviewerID)
-				analysis, err := analyzeReview(ctx, client, projectID, locationID, r.ReviewText)
-
-				analyzedReview := ReviewWithAnalysis{Review: r}
-				if err != nil {
-					log.Printf("    Error analyzing review %s: %v\n", r.ReviewText, err)
-					analyzedReview.Error = err.Error()
-				} else {
-					analyzedReview.AnalysisResult = analysis
-				}
-
-				// Use a mutex if writing to a shared slice directly,
-				// but for simplicity, we'll append after the loop finishes
-				// or to a channel if you need real-time aggregation.
-				// For now, collecting after all are done.
-				allAnalyzedReviews = append(allAnalyzedReviews, analyzedReview)
-
-			}(review)
+
+		var result analyzer.AnalysisResult
+		if err := json.Unmarshal([]byte(job.ResultJSON), &result); err != nil {
+			slog.Error("decoding stored result", "job_id", job.ID, "error", err)
+			continue
 		}
-		// Wait for the current batch to complete before potentially starting a new one
-		// or just let them run if there are no rate limits issues.
-		// For smaller batch sizes and potential rate limits, you might want to uncomment:
-		// wg.Wait() // Wait for current batch to finish before proceeding to next batch (if any)
-		// time.Sleep(1 * time.Second) // Small delay to prevent hitting rate limits
+
+		allAnalyzedReviews = append(allAnalyzedReviews, ReviewWithAnalysis{Review: review, AnalysisResult: &result})
 	}
 
-	wg.Wait() // Wait for all goroutines from all batches to finish
+	failed, err := store.Failed()
+	if err != nil {
+		log.Fatalf("Failed to collect failed jobs: %v", err)
+	}
+	for _, job := range failed {
+		review, ok := reviewsByJobID[job.ID]
+		if !ok {
+			continue
+		}
+		allAnalyzedReviews = append(allAnalyzedReviews, ReviewWithAnalysis{Review: review, Error: job.LastError})
+	}
 
 	// Output results
 	outputJSON, err := json.MarshalIndent(allAnalyzedReviews, "", "  ")
@@ -242,9 +326,8 @@ func main() {
 
 	// Optionally save to a file
 	outputFileName := "analyzed_reviews.json"
-	err = os.WriteFile(outputFileName, outputJSON, 0644)
-	if err != nil {
+	if err := os.WriteFile(outputFileName, outputJSON, 0644); err != nil {
 		log.Fatalf("Error writing output to file %s: %v", outputFileName, err)
 	}
-	log.Printf("Analysis results saved to %s\n", outputFileName)
+	slog.Info("analysis results saved", "path", outputFileName)
 }
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// requireAuth wraps next with a check that the request carries
+// "Authorization: Bearer <key>" matching s.apiKey. If s.apiKey is empty,
+// auth is disabled entirely (local development against a backend that
+// itself requires no key).
+func (s *server) requireAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.apiKey == "" {
+			next(w, r)
+			return
+		}
+
+		token, hasBearer := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !hasBearer || token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.apiKey)) != 1 {
+			writeError(w, http.StatusUnauthorized, "invalid or missing API key")
+			return
+		}
+		next(w, r)
+	}
+}
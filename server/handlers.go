@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/hardrich/competitor_weakness_gpt/analyzer"
+)
+
+// Review mirrors script.Review; this binary only decodes/encodes the
+// wire shape, so it keeps its own copy rather than depending on the
+// script package (see report/main.go for the same tradeoff).
+type Review struct {
+	ReviewerID     string  `json:"reviewerID"`
+	ASIN           string  `json:"asin"`
+	ReviewerName   string  `json:"reviewerName"`
+	ReviewText     string  `json:"reviewText"`
+	Overall        float64 `json:"overall"`
+	UnixReviewTime int64   `json:"unixReviewTime"`
+	ReviewTime     string  `json:"reviewTime"`
+}
+
+// ReviewWithAnalysis mirrors script.ReviewWithAnalysis, the shape
+// returned by /v1/analyze.
+type ReviewWithAnalysis struct {
+	Review
+	AnalysisResult *analyzer.AnalysisResult
+	Error          string `json:"error,omitempty"`
+}
+
+// analyzeRequest is the body for POST /v1/analyze.
+type analyzeRequest struct {
+	Reviews []Review `json:"reviews"`
+	Stream  bool     `json:"stream,omitempty"`
+}
+
+// handleAnalyze runs every review in the request through the analyzer
+// backend and returns a ReviewWithAnalysis per review, either as one
+// JSON array or, if the caller set "stream": true, as an SSE event per
+// review as soon as it finishes.
+func (s *server) handleAnalyze(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+
+	var req analyzeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("decoding request body: %v", err))
+		return
+	}
+
+	if req.Stream {
+		s.streamAnalyze(w, r, req.Reviews)
+		return
+	}
+
+	results := make([]ReviewWithAnalysis, len(req.Reviews))
+	for i, review := range req.Reviews {
+		results[i] = s.analyzeOne(r.Context(), review)
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+// streamAnalyze sends one SSE event per analyzed review as soon as it's
+// done, so a client doesn't have to wait for the whole batch before
+// seeing the first result.
+func (s *server) streamAnalyze(w http.ResponseWriter, r *http.Request, reviews []Review) {
+	stream, ok := newSSEWriter(w)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming not supported by this connection")
+		return
+	}
+
+	for _, review := range reviews {
+		result := s.analyzeOne(r.Context(), review)
+		if err := stream.writeJSON(result); err != nil {
+			return
+		}
+	}
+	stream.writeDone()
+}
+
+func (s *server) analyzeOne(ctx context.Context, review Review) ReviewWithAnalysis {
+	analyzed := ReviewWithAnalysis{Review: review}
+	result, err := s.analyzer.Analyze(ctx, review.ReviewText)
+	if err != nil {
+		analyzed.Error = err.Error()
+		return analyzed
+	}
+	analyzed.AnalysisResult = result
+	return analyzed
+}
+
+// chatMessage is the OpenAI chat message shape: a role and its text.
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content,omitempty"`
+}
+
+// chatCompletionRequest is the subset of OpenAI's /v1/chat/completions
+// request body this server understands: the last "user" message's
+// content is treated as the review text to analyze.
+type chatCompletionRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+	Stream   bool          `json:"stream,omitempty"`
+}
+
+type chatCompletionChoice struct {
+	Index        int         `json:"index"`
+	Message      chatMessage `json:"message"`
+	FinishReason *string     `json:"finish_reason"`
+}
+
+type chatCompletionResponse struct {
+	ID      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []chatCompletionChoice `json:"choices"`
+}
+
+type chatCompletionChunkChoice struct {
+	Index        int         `json:"index"`
+	Delta        chatMessage `json:"delta"`
+	FinishReason *string     `json:"finish_reason"`
+}
+
+type chatCompletionChunk struct {
+	ID      string                      `json:"id"`
+	Object  string                      `json:"object"`
+	Created int64                       `json:"created"`
+	Model   string                      `json:"model"`
+	Choices []chatCompletionChunkChoice `json:"choices"`
+}
+
+var completionCounter atomic.Int64
+
+// completionID returns a unique-enough id for a chat completion: a
+// timestamp plus a process-local counter, in the "chatcmpl-..." shape
+// OpenAI clients expect to see but never actually parse.
+func completionID() string {
+	return fmt.Sprintf("chatcmpl-%d-%d", time.Now().UnixNano(), completionCounter.Add(1))
+}
+
+// handleChatCompletions serves a subset of POST /v1/chat/completions:
+// the analysis result for the last user message is returned as the
+// assistant's (JSON-encoded) message content, so any client already
+// speaking OpenAI's wire format can consume weakness analysis without
+// knowing this isn't actually a chat model.
+func (s *server) handleChatCompletions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "only POST is supported")
+		return
+	}
+
+	var req chatCompletionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("decoding request body: %v", err))
+		return
+	}
+
+	reviewText := lastUserMessage(req.Messages)
+	if reviewText == "" {
+		writeError(w, http.StatusBadRequest, "no user message found to analyze")
+		return
+	}
+
+	model := req.Model
+	if model == "" {
+		model = s.model
+	}
+
+	result, err := s.analyzer.Analyze(r.Context(), reviewText)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Sprintf("analyzer: %v", err))
+		return
+	}
+
+	content, err := json.Marshal(result)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Sprintf("marshaling analysis result: %v", err))
+		return
+	}
+
+	if req.Stream {
+		s.streamChatCompletion(w, model, string(content))
+		return
+	}
+
+	finishReason := "stop"
+	writeJSON(w, http.StatusOK, chatCompletionResponse{
+		ID:      completionID(),
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   model,
+		Choices: []chatCompletionChoice{
+			{Index: 0, Message: chatMessage{Role: "assistant", Content: string(content)}, FinishReason: &finishReason},
+		},
+	})
+}
+
+// streamChatCompletion emits the analysis result as a single content
+// delta followed by a finish-reason chunk. The analyzer produces its
+// whole result in one shot, so there's no meaningful token-by-token
+// stream to forward — this just keeps the framing identical to a real
+// streaming chat completion for clients that expect it.
+func (s *server) streamChatCompletion(w http.ResponseWriter, model, content string) {
+	stream, ok := newSSEWriter(w)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming not supported by this connection")
+		return
+	}
+
+	id := completionID()
+	created := time.Now().Unix()
+
+	contentChunk := chatCompletionChunk{
+		ID: id, Object: "chat.completion.chunk", Created: created, Model: model,
+		Choices: []chatCompletionChunkChoice{
+			{Index: 0, Delta: chatMessage{Role: "assistant", Content: content}},
+		},
+	}
+	if err := stream.writeJSON(contentChunk); err != nil {
+		return
+	}
+
+	finishReason := "stop"
+	finalChunk := chatCompletionChunk{
+		ID: id, Object: "chat.completion.chunk", Created: created, Model: model,
+		Choices: []chatCompletionChunkChoice{
+			{Index: 0, Delta: chatMessage{}, FinishReason: &finishReason},
+		},
+	}
+	if err := stream.writeJSON(finalChunk); err != nil {
+		return
+	}
+	stream.writeDone()
+}
+
+func lastUserMessage(messages []chatMessage) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+// modelInfo is one entry of the OpenAI-shaped GET /v1/models listing.
+type modelInfo struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	OwnedBy string `json:"owned_by"`
+}
+
+func (s *server) handleModels(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		Object string      `json:"object"`
+		Data   []modelInfo `json:"data"`
+	}{
+		Object: "list",
+		Data:   []modelInfo{{ID: s.model, Object: "model", OwnedBy: "competitor_weakness_gpt"}},
+	})
+}
+
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	resp := errorResponse{}
+	resp.Error.Message = message
+	writeJSON(w, status, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
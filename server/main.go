@@ -0,0 +1,108 @@
+// Command server exposes the analyzer pipeline over HTTP, speaking a
+// subset of OpenAI's /v1/chat/completions wire format plus a
+// purpose-built /v1/analyze endpoint. This lets other services
+// (dashboards, Zapier-style flows, chat UIs) consume weakness analysis
+// without importing the Go code, and mirrors the ecosystem convention
+// that most LLM tooling already speaks OpenAI's wire format.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/hardrich/competitor_weakness_gpt/analyzer"
+	"github.com/joho/godotenv"
+)
+
+const (
+	defaultGeminiModelDisplay = "gemini-2.5-pro"
+	defaultOpenAIModelDisplay = "gpt-4o-mini"
+)
+
+// server holds the shared state every handler needs: the analyzer
+// backend itself, the model name to advertise over the wire, and the
+// API key (if any) clients must present.
+type server struct {
+	analyzer analyzer.Analyzer
+	model    string
+	apiKey   string
+}
+
+// loadAnalyzerConfig builds an analyzer.Config from the environment, the
+// same way script/script.go does: the backend defaults to Gemini but
+// can be switched with ANALYZER_BACKEND=openai|grpc. Unlike the script,
+// it does not read ANALYZER_BACKENDS_DIR or launch backend plugins, so
+// the grpc backend needs ANALYZER_GRPC_ADDR to be set.
+func loadAnalyzerConfig() analyzer.Config {
+	backend := analyzer.Backend(os.Getenv("ANALYZER_BACKEND"))
+
+	return analyzer.Config{
+		Backend: backend,
+
+		GeminiAPIKey: os.Getenv("GEMINI_API_KEY"),
+		GeminiModel:  os.Getenv("GEMINI_MODEL"),
+
+		OpenAIBaseURL: os.Getenv("OPENAI_BASE_URL"),
+		OpenAIAPIKey:  os.Getenv("OPENAI_API_KEY"),
+		OpenAIModel:   os.Getenv("OPENAI_MODEL"),
+
+		GRPCAddr: os.Getenv("ANALYZER_GRPC_ADDR"),
+	}
+}
+
+// modelDisplayName returns the model identifier to advertise over
+// /v1/models and echo back in chat completions, mirroring whichever
+// default the chosen backend itself falls back to.
+func modelDisplayName(cfg analyzer.Config) string {
+	switch cfg.Backend {
+	case analyzer.BackendOpenAI:
+		if cfg.OpenAIModel != "" {
+			return cfg.OpenAIModel
+		}
+		return defaultOpenAIModelDisplay
+	case analyzer.BackendGRPC:
+		return "grpc-plugin"
+	default:
+		if cfg.GeminiModel != "" {
+			return cfg.GeminiModel
+		}
+		return defaultGeminiModelDisplay
+	}
+}
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	apiKey := flag.String("api-key", os.Getenv("SERVER_API_KEY"), "API key clients must send as 'Authorization: Bearer <key>' (empty disables auth)")
+	flag.Parse()
+
+	if err := godotenv.Load(); err != nil {
+		log.Printf("No .env file loaded, continuing with process environment: %v", err)
+	}
+
+	cfg := loadAnalyzerConfig()
+	client, err := analyzer.New(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize analyzer backend: %v", err)
+	}
+	defer client.Close()
+
+	srv := &server{analyzer: client, model: modelDisplayName(cfg), apiKey: *apiKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/models", srv.requireAuth(srv.handleModels))
+	mux.HandleFunc("/v1/analyze", srv.requireAuth(srv.handleAnalyze))
+	mux.HandleFunc("/v1/chat/completions", srv.requireAuth(srv.handleChatCompletions))
+
+	httpServer := &http.Server{
+		Addr:         *addr,
+		Handler:      mux,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 5 * time.Minute, // long batches can take a while to stream
+	}
+
+	log.Printf("Listening on %s (model=%s)\n", *addr, srv.model)
+	log.Fatal(httpServer.ListenAndServe())
+}